@@ -0,0 +1,118 @@
+package melt
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// armorParitySymbols is the number of Reed–Solomon parity words (2t, t=3)
+// appended to an armored mnemonic. It corrects up to 3 substituted words, or
+// up to 6 words marked with the erasure token.
+const armorParitySymbols = 6
+
+// erasureToken marks a word the user could not recall, in place of guessing.
+// Up to armorParitySymbols of these can be recovered on restore.
+const erasureToken = "?"
+
+// ToMnemonicArmored behaves like ToMnemonic, but appends a Reed–Solomon
+// parity tail drawn from the same active BIP-39 wordlist. The tail lets
+// FromMnemonicArmored correct a handful of misremembered or miscopied words
+// instead of merely detecting that something is wrong.
+//
+// Armored phrases are not interchangeable with stock BIP-39 wallets: only
+// the first 24 words form a valid BIP-39 phrase, and tools that don't know
+// about the parity tail will reject the extra words.
+func ToMnemonicArmored(key *ed25519.PrivateKey) (string, error) {
+	phrase, err := ToMnemonic(key)
+	if err != nil {
+		return "", err
+	}
+
+	wordlist := bip39.GetWordList()
+	index, err := wordIndex(wordlist)
+	if err != nil {
+		return "", err
+	}
+
+	words := strings.Fields(phrase)
+	symbols := make([]uint16, len(words))
+	for i, w := range words {
+		symbols[i] = index[w]
+	}
+
+	codeword := rsEncode(symbols, armorParitySymbols)
+	parity := codeword[len(symbols):]
+
+	out := make([]string, 0, len(codeword))
+	out = append(out, words...)
+	for _, p := range parity {
+		out = append(out, wordlist[p])
+	}
+	return strings.Join(out, " "), nil
+}
+
+// FromMnemonicArmored reverses ToMnemonicArmored. Any word the caller
+// couldn't recall should be replaced with the erasure token ("?") rather
+// than a guess: erasures at known positions are cheaper to correct than
+// errors at unknown ones, so marking them doubles the number of mistakes
+// FromMnemonicArmored can recover from.
+func FromMnemonicArmored(mnemonic string) (ed25519.PrivateKey, error) {
+	phrase, err := dearmor(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return FromMnemonic(phrase)
+}
+
+func dearmor(mnemonic string) (string, error) {
+	wordlist := bip39.GetWordList()
+	index, err := wordIndex(wordlist)
+	if err != nil {
+		return "", err
+	}
+
+	tokens := strings.Fields(mnemonic)
+	if len(tokens) <= armorParitySymbols {
+		return "", fmt.Errorf("armored mnemonic is too short: expected more than %d words, got %d", armorParitySymbols, len(tokens))
+	}
+
+	symbols := make([]uint16, len(tokens))
+	var erasures []int
+	for i, tok := range tokens {
+		if tok == erasureToken {
+			erasures = append(erasures, i)
+			continue
+		}
+		idx, ok := index[tok]
+		if !ok {
+			return "", fmt.Errorf("word %q is not in the active wordlist", tok)
+		}
+		symbols[i] = idx
+	}
+
+	corrected, err := rsDecode(symbols, armorParitySymbols, erasures)
+	if err != nil {
+		return "", fmt.Errorf("could not correct armored mnemonic: %w", err)
+	}
+
+	phraseWords := corrected[:len(corrected)-armorParitySymbols]
+	words := make([]string, len(phraseWords))
+	for i, sym := range phraseWords {
+		words[i] = wordlist[sym]
+	}
+	return strings.Join(words, " "), nil
+}
+
+func wordIndex(wordlist []string) (map[string]uint16, error) {
+	if len(wordlist) != gfSize {
+		return nil, fmt.Errorf("active wordlist has %d words, want %d to armor", len(wordlist), gfSize)
+	}
+	index := make(map[string]uint16, len(wordlist))
+	for i, w := range wordlist {
+		index[w] = uint16(i)
+	}
+	return index, nil
+}
@@ -0,0 +1,76 @@
+package melt
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestArmorRoundTrip(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonicArmored(&key)
+	is.NoErr(err)
+	is.Equal(len(strings.Fields(mnemonic)), 24+armorParitySymbols)
+
+	got, err := FromMnemonicArmored(mnemonic)
+	is.NoErr(err)
+	is.Equal(got, key)
+}
+
+func TestArmorCorrectsSubstitutedWords(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonicArmored(&key)
+	is.NoErr(err)
+
+	words := strings.Fields(mnemonic)
+	words[0], words[5], words[10] = "abandon", "zoo", "ability"
+
+	got, err := FromMnemonicArmored(strings.Join(words, " "))
+	is.NoErr(err)
+	is.Equal(got, key)
+}
+
+func TestArmorCorrectsErasures(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonicArmored(&key)
+	is.NoErr(err)
+
+	words := strings.Fields(mnemonic)
+	for _, i := range []int{1, 4, 9, 15, 20, 29} {
+		words[i] = erasureToken
+	}
+
+	got, err := FromMnemonicArmored(strings.Join(words, " "))
+	is.NoErr(err)
+	is.Equal(got, key)
+}
+
+func TestArmorUnknownWordFails(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonicArmored(&key)
+	is.NoErr(err)
+
+	words := strings.Fields(mnemonic)
+	words[0] = "notaword"
+
+	_, err = FromMnemonicArmored(strings.Join(words, " "))
+	is.True(err != nil)
+}
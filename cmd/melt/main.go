@@ -2,7 +2,10 @@ package main
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -46,6 +49,15 @@ var (
 
 	mnemonic string
 	language string
+	armor    bool
+	keyType  string
+
+	shamirThreshold  int
+	shamirShareCount int
+	shamirPassphrase string
+	shamirShares     []string
+
+	mnemonicPassphrase string
 
 	rootCmd = &cobra.Command{
 		Use: "melt",
@@ -63,12 +75,22 @@ be used to rebuild your public and private keys.`,
 				return err
 			}
 
+			mnemonicPass, err := resolveMnemonicPassphrase(cmd)
+			if err != nil {
+				return err
+			}
+			if mnemonicPass != "" {
+				if err := confirmMnemonicPassphraseGate(); err != nil {
+					return err
+				}
+			}
+
 			var keyPath string
 			if len(args) > 0 {
 				keyPath = args[0]
 			}
 
-			mnemonic, err := backup(keyPath, nil)
+			mnemonic, err := backup(keyPath, nil, armor, mnemonicPass)
 			if err != nil {
 				return err
 			}
@@ -115,23 +137,44 @@ be used to rebuild your public and private keys.`,
 	restoreCmd = &cobra.Command{
 		Use:   "restore",
 		Short: "Recreate a key using the given seed phrase",
+		Long: `restore recreates a key from a seed phrase. --language is optional: if
+omitted, the phrase's wordlist is detected automatically and reported on
+stderr. If the phrase was melted down with --mnemonic-passphrase, the same
+passphrase must be given here, or a different, equally valid-looking key
+is silently restored instead.`,
 		Example: `  melt restore --seed "seed phrase" ./restored_id25519
   melt restore ./restored_id25519 < seed`,
 		Aliases: []string{"res", "r"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := setLanguage(language); err != nil {
+			seed := maybeFile(mnemonic)
+
+			if cmd.Flags().Changed("language") {
+				if err := setLanguage(language); err != nil {
+					return err
+				}
+			} else if err := detectAndSetLanguage(seed); err != nil {
+				return err
+			}
+
+			mnemonicPass, err := resolveMnemonicPassphrase(cmd)
+			if err != nil {
 				return err
 			}
+			if mnemonicPass != "" {
+				if err := confirmMnemonicPassphraseGate(); err != nil {
+					return err
+				}
+			}
 
 			switch args[0] {
 			case "-":
 				_, _ = fmt.Fprint(os.Stderr, "Restoring key to STDOUT...\n")
-				return restore(maybeFile(mnemonic), askNewPassphrase, restoreToWriter(cmd.OutOrStdout()))
+				return restore(seed, armor, keyType, mnemonicPass, askNewPassphrase, restoreToWriter(cmd.OutOrStdout()))
 			default:
 				name := args[0]
 				_, _ = fmt.Fprintf(os.Stderr, "Restoring key to %s and %[1]s.pub...\n", name)
-				if err := restore(maybeFile(mnemonic), askNewPassphrase, restoreToFiles(name)); err != nil {
+				if err := restore(seed, armor, keyType, mnemonicPass, askNewPassphrase, restoreToFiles(name)); err != nil {
 					return err
 				}
 
@@ -143,6 +186,78 @@ be used to rebuild your public and private keys.`,
 		},
 	}
 
+	splitCmd = &cobra.Command{
+		Use:   "split",
+		Short: "Split an SSH key into Shamir secret-sharing shares (melt's own format, not interoperable with SLIP-39 tools)",
+		Example: `  melt split ~/.ssh/id_ed25519 --threshold 2 --shares 3
+  melt split ~/.ssh/id_ed25519 --threshold 2 --shares 3 --passphrase`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pass, err := resolveShamirPassphrase(cmd)
+			if err != nil {
+				return err
+			}
+
+			shares, err := split(args[0], nil, shamirThreshold, shamirShareCount, pass)
+			if err != nil {
+				return err
+			}
+
+			if isatty.IsTerminal(os.Stdout.Fd()) {
+				b := strings.Builder{}
+				w := getWidth(maxWidth)
+				b.WriteRune('\n')
+				renderBlock(&b, baseStyle, w, fmt.Sprintf("OK! Your key has been split into %d shares, any %d of which restore it. Store them somewhere safe, and apart from each other.", shamirShareCount, shamirThreshold))
+				for i, s := range shares {
+					renderBlock(&b, baseStyle, w, fmt.Sprintf("Share %d of %d:", i+1, len(shares)))
+					renderBlock(&b, mnemonicStyle, w, s)
+				}
+				fmt.Println(b.String())
+			} else {
+				for _, s := range shares {
+					fmt.Println(s)
+				}
+			}
+			return nil
+		},
+	}
+
+	combineCmd = &cobra.Command{
+		Use:   "combine",
+		Short: "Recreate a key from Shamir secret-sharing shares produced by melt split",
+		Example: `  melt combine ./restored_id25519 --share "share one..." --share "share two..."
+  melt combine ./restored_id25519 < shares.txt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shares, err := collectShares(shamirShares)
+			if err != nil {
+				return err
+			}
+
+			pass, err := resolveShamirPassphrase(cmd)
+			if err != nil {
+				return err
+			}
+
+			pvtKey, err := melt.FromShamirShares(shares, pass)
+			if err != nil {
+				//nolint: wrapcheck
+				return err
+			}
+
+			name := args[0]
+			_, _ = fmt.Fprintf(os.Stderr, "Restoring key to %s and %[1]s.pub...\n", name)
+			if err := writeKey(pvtKey, askNewPassphrase, restoreToFiles(name)); err != nil {
+				return err
+			}
+
+			pub := keyPathStyle.Render(name)
+			priv := keyPathStyle.Render(name + ".pub")
+			fmt.Println(baseStyle.Render(fmt.Sprintf("\nSuccessfully restored keys to %s and %s", pub, priv)))
+			return nil
+		},
+	}
+
 	manCmd = &cobra.Command{
 		Use:          "man",
 		Args:         cobra.NoArgs,
@@ -165,10 +280,23 @@ be used to rebuild your public and private keys.`,
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&language, "language", "l", "en", "Language")
-	rootCmd.AddCommand(restoreCmd, manCmd)
+	rootCmd.PersistentFlags().BoolVar(&armor, "armor", false, "Add a Reed–Solomon parity tail that can correct a few misremembered or erased (\"?\") words")
+	rootCmd.PersistentFlags().StringVar(&mnemonicPassphrase, "mnemonic-passphrase", "", "Passphrase mixed into the mnemonic itself as an independent encryption factor (pass with no value to be prompted)")
+	rootCmd.PersistentFlags().Lookup("mnemonic-passphrase").NoOptDefVal = mnemonicPassphrasePrompt
+	rootCmd.AddCommand(restoreCmd, splitCmd, combineCmd, manCmd)
 
 	restoreCmd.PersistentFlags().StringVarP(&mnemonic, "seed", "s", "-", "Seed phrase")
 	_ = restoreCmd.MarkFlagRequired("seed")
+	restoreCmd.PersistentFlags().StringVar(&keyType, "key-type", "ed25519", "Type of key the seed phrase encodes: only ed25519 is supported; rsa and ecdsa always fail (not deterministically reproducible)")
+
+	splitCmd.Flags().IntVar(&shamirThreshold, "threshold", 2, "Number of shares required to restore the key") //nolint: gomnd
+	splitCmd.Flags().IntVar(&shamirShareCount, "shares", 3, "Total number of shares to generate")              //nolint: gomnd
+	splitCmd.Flags().StringVar(&shamirPassphrase, "passphrase", "", "Passphrase that is mixed into the split secret itself (pass with no value to be prompted)")
+	splitCmd.Flags().Lookup("passphrase").NoOptDefVal = shamirPassphrasePrompt
+
+	combineCmd.Flags().StringArrayVar(&shamirShares, "share", nil, "A share mnemonic; repeat once per share, or omit and pipe shares on stdin")
+	combineCmd.Flags().StringVar(&shamirPassphrase, "passphrase", "", "Passphrase given at split time (pass with no value to be prompted)")
+	combineCmd.Flags().Lookup("passphrase").NoOptDefVal = shamirPassphrasePrompt
 }
 
 func main() {
@@ -215,7 +343,7 @@ func parsePrivateKey(bts, pass []byte) (interface{}, error) {
 	return ssh.ParseRawPrivateKeyWithPassphrase(bts, pass)
 }
 
-func backup(path string, pass []byte) (string, error) {
+func backup(path string, pass []byte, armor bool, mnemonicPass string) (string, error) {
 	f, err := openFileOrStdin(path)
 	if err != nil {
 		return "", fmt.Errorf("could not read key: %w", err)
@@ -232,7 +360,7 @@ func backup(path string, pass []byte) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		return backup(path, pass)
+		return backup(path, pass, armor, mnemonicPass)
 	}
 	if err != nil {
 		return "", fmt.Errorf("could not parse key: %w", err)
@@ -240,8 +368,28 @@ func backup(path string, pass []byte) (string, error) {
 
 	switch key := key.(type) {
 	case *ed25519.PrivateKey:
+		if armor {
+			if mnemonicPass != "" {
+				return "", fmt.Errorf("--armor does not support --mnemonic-passphrase")
+			}
+			//nolint: wrapcheck
+			return melt.ToMnemonicArmored(key)
+		}
+		if mnemonicPass != "" {
+			//nolint: wrapcheck
+			return melt.ToMnemonic(key, mnemonicPass)
+		}
 		//nolint: wrapcheck
 		return melt.ToMnemonic(key)
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		if armor {
+			return "", fmt.Errorf("--armor is only supported for ed25519 keys")
+		}
+		if mnemonicPass != "" {
+			return "", fmt.Errorf("--mnemonic-passphrase is only supported for ed25519 keys")
+		}
+		//nolint: wrapcheck
+		return melt.ToMnemonicKey(key)
 	default:
 		return "", fmt.Errorf("unknown key type: %v", key)
 	}
@@ -252,7 +400,7 @@ func isPasswordError(err error) bool {
 	return errors.As(err, &kerr)
 }
 
-func marshallPrivateKey(key ed25519.PrivateKey, pass []byte) (*pem.Block, error) {
+func marshallPrivateKey(key crypto.Signer, pass []byte) (*pem.Block, error) {
 	if len(pass) == 0 {
 		//nolint: wrapcheck
 		return ssh.MarshalPrivateKey(key, "")
@@ -261,13 +409,54 @@ func marshallPrivateKey(key ed25519.PrivateKey, pass []byte) (*pem.Block, error)
 	return ssh.MarshalPrivateKeyWithPassphrase(key, "", pass)
 }
 
-func restore(mnemonic string, passFn func() ([]byte, error), outFn func(pem, pub []byte) error) error {
-	pvtKey, err := melt.FromMnemonic(mnemonic)
-	if err != nil {
-		//nolint: wrapcheck
-		return err
+func restore(mnemonic string, armor bool, keyType string, mnemonicPass string, passFn func() ([]byte, error), outFn func(pem, pub []byte) error) error {
+	var pvtKey crypto.Signer
+	switch keyType {
+	case "", "ed25519":
+		var key ed25519.PrivateKey
+		var err error
+		switch {
+		case armor:
+			if mnemonicPass != "" {
+				return fmt.Errorf("--armor does not support --mnemonic-passphrase")
+			}
+			key, err = melt.FromMnemonicArmored(mnemonic)
+		case mnemonicPass != "":
+			key, err = melt.FromMnemonic(mnemonic, mnemonicPass)
+		default:
+			key, err = melt.FromMnemonic(mnemonic)
+		}
+		if err != nil {
+			//nolint: wrapcheck
+			return err
+		}
+		pvtKey = key
+	case "rsa", "ecdsa":
+		if armor {
+			return fmt.Errorf("--armor is only supported for ed25519 keys")
+		}
+		if mnemonicPass != "" {
+			return fmt.Errorf("--mnemonic-passphrase is only supported for ed25519 keys")
+		}
+		key, err := melt.FromMnemonicKey(mnemonic)
+		if err != nil {
+			//nolint: wrapcheck
+			return err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return fmt.Errorf("mnemonic did not decode to a usable %s key", keyType)
+		}
+		pvtKey = signer
+	default:
+		return fmt.Errorf("unknown --key-type %q: want ed25519 (rsa and ecdsa are accepted but always fail, see --help)", keyType)
 	}
+	return writeKey(pvtKey, passFn, outFn)
+}
 
+// writeKey marshals a recovered private key and hands its PEM and public
+// key bytes to outFn; shared by restore and combine.
+func writeKey(pvtKey crypto.Signer, passFn func() ([]byte, error), outFn func(pem, pub []byte) error) error {
 	pass, err := passFn()
 	if err != nil {
 		return err
@@ -286,6 +475,63 @@ func restore(mnemonic string, passFn func() ([]byte, error), outFn func(pem, pub
 	return outFn(pem.EncodeToMemory(block), ssh.MarshalAuthorizedKey(pubkey))
 }
 
+// split reads the key at path and divides it into count Shamir shares, any
+// threshold of which later restore it via combine.
+func split(path string, pass []byte, threshold, count int, mnemonicPass string) ([]string, error) {
+	f, err := openFileOrStdin(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	bts, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key: %w", err)
+	}
+
+	key, err := parsePrivateKey(bts, pass)
+	if err != nil && isPasswordError(err) {
+		pass, err := askKeyPassphrase(path)
+		if err != nil {
+			return nil, err
+		}
+		return split(path, pass, threshold, count, mnemonicPass)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *ed25519.PrivateKey:
+		//nolint: wrapcheck
+		return melt.ToShamirShares(key, threshold, count, mnemonicPass)
+	default:
+		return nil, fmt.Errorf("unknown key type: %v", key)
+	}
+}
+
+// collectShares returns shares as given on the command line, or, if none
+// were given, reads newline-separated shares from stdin.
+func collectShares(shares []string) ([]string, error) {
+	if len(shares) > 0 {
+		return shares, nil
+	}
+
+	bts, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("could not read shares from stdin: %w", err)
+	}
+	var out []string
+	for _, line := range strings.Split(string(bts), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no shares given: pass --share or pipe shares on stdin")
+	}
+	return out, nil
+}
+
 func restoreToWriter(w io.Writer) func(pem, _ []byte) error {
 	return func(pem, _ []byte) error {
 		if _, err := fmt.Fprint(w, string(pem)); err != nil {
@@ -342,6 +588,25 @@ func setLanguage(language string) error {
 	return nil
 }
 
+// detectAndSetLanguage is used by restore when the user didn't pass
+// --language explicitly: it sniffs the wordlist a seed phrase belongs to
+// and sets it as the active bip39 wordlist, reporting what it found.
+func detectAndSetLanguage(seed string) error {
+	tag, err := melt.DetectLanguage(seed)
+	if err != nil {
+		return fmt.Errorf("%w (pass --language explicitly if you know it)", err)
+	}
+
+	list, ok := wordLists[tag]
+	if !ok {
+		return fmt.Errorf("detected language %s has no matching wordlist", tag)
+	}
+	bip39.SetWordList(list)
+
+	_, _ = fmt.Fprintf(os.Stderr, "Detected language: %s\n", display.English.Languages().Name(tag))
+	return nil
+}
+
 func sanitizeLang(s string) string {
 	return strings.ReplaceAll(strings.ToLower(s), " ", "-")
 }
@@ -399,6 +664,81 @@ func readPassword(msg string) ([]byte, error) {
 	return pass, nil
 }
 
+// mnemonicPassphrasePrompt is --mnemonic-passphrase's NoOptDefVal: pflag
+// only skips consuming the next argument as a flag's value when NoOptDefVal
+// is non-empty, so an empty NoOptDefVal doesn't actually make the value
+// optional, it just makes the flag behave as if NoOptDefVal were unset and
+// swallow whatever follows (e.g. the key path) as the passphrase. Using a
+// sentinel no real passphrase would collide with lets resolveMnemonicPassphrase
+// tell "flag given bare" apart from "flag given with this literal value".
+const mnemonicPassphrasePrompt = "\x00prompt"
+
+// resolveMnemonicPassphrase returns the configured --mnemonic-passphrase,
+// prompting interactively via readPassword if the flag was given with no
+// value so the passphrase never has to appear in shell history.
+func resolveMnemonicPassphrase(cmd *cobra.Command) (string, error) {
+	if !cmd.Flags().Changed("mnemonic-passphrase") {
+		return "", nil
+	}
+	if mnemonicPassphrase != mnemonicPassphrasePrompt {
+		return mnemonicPassphrase, nil
+	}
+	defer fmt.Fprintf(os.Stderr, "\n")
+	pass, err := readPassword("Enter mnemonic passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	return string(pass), nil
+}
+
+// shamirPassphrasePrompt is split/combine's --passphrase NoOptDefVal; see
+// mnemonicPassphrasePrompt for why it has to be a non-empty sentinel rather
+// than "".
+const shamirPassphrasePrompt = "\x00prompt"
+
+// resolveShamirPassphrase returns the configured --passphrase for split or
+// combine, prompting interactively via readPassword if the flag was given
+// with no value so the passphrase never has to appear in shell history.
+func resolveShamirPassphrase(cmd *cobra.Command) (string, error) {
+	if !cmd.Flags().Changed("passphrase") {
+		return "", nil
+	}
+	if shamirPassphrase != shamirPassphrasePrompt {
+		return shamirPassphrase, nil
+	}
+	defer fmt.Fprintf(os.Stderr, "\n")
+	pass, err := readPassword("Enter passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	return string(pass), nil
+}
+
+// confirmMnemonicPassphraseGate warns that, unlike a key-file passphrase, a
+// wrong mnemonic passphrase doesn't fail restore: it silently hands back a
+// different, equally valid-looking key. On an interactive stdout it refuses
+// to proceed without an explicit "yes", so that property can't bite someone
+// who didn't mean to rely on it.
+func confirmMnemonicPassphraseGate() error {
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return nil
+	}
+
+	_, _ = fmt.Fprint(os.Stderr, "Warning: a wrong mnemonic passphrase silently restores a different, equally valid-looking key instead of failing. Continue? (yes/no): ")
+	t, err := tty.Open()
+	if err != nil {
+		return fmt.Errorf("could not open tty: %w", err)
+	}
+	defer t.Close() //nolint: errcheck
+
+	var resp string
+	_, _ = fmt.Fscanln(t.Input(), &resp)
+	if strings.ToLower(strings.TrimSpace(resp)) != "yes" {
+		return fmt.Errorf("aborted: mnemonic passphrase not confirmed")
+	}
+	return nil
+}
+
 func askKeyPassphrase(path string) ([]byte, error) {
 	defer fmt.Fprintf(os.Stderr, "\n")
 	return readPassword(fmt.Sprintf("Enter the passphrase to unlock %q: ", path))
@@ -0,0 +1,40 @@
+package melt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+)
+
+// errKeygenNotDeterministic explains why ToMnemonicKey and FromMnemonicKey
+// refuse to handle RSA and ECDSA keys at all: crypto/internal/randutil's
+// MaybeReadByte, called unconditionally from both rsa.GenerateKey and
+// ecdsa.GenerateKey, decides with a select statement on an already-closed
+// channel whether to consume a byte from the supplied rand.Reader before
+// generating the key. That decision is made by the Go runtime's own
+// scheduler, not derived from the reader's bytes, so it isn't reproducible
+// even across two calls in the same process given the same deterministic
+// stream — there is no Go version this could be pinned to, and it affects
+// ECDSA exactly as much as RSA. Restoring from a mnemonic therefore can't be
+// made to reliably reconstruct the original key; since silently handing
+// back a different, equally valid-looking key is worse than failing, melt
+// refuses both directions rather than pretend to support them.
+var errKeygenNotDeterministic = fmt.Errorf("RSA and ECDSA keys cannot be backed up this way: crypto/rsa and crypto/ecdsa key generation is not reproducible from a deterministic random stream, so a restored key would not be guaranteed to match the original")
+
+// ToMnemonicKey would render key as a BIP-39 mnemonic, extending melt beyond
+// ed25519 to *rsa.PrivateKey and *ecdsa.PrivateKey. It always fails: see
+// errKeygenNotDeterministic.
+func ToMnemonicKey(key interface{}) (string, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return "", errKeygenNotDeterministic
+	default:
+		return "", fmt.Errorf("unsupported key type for ToMnemonicKey: %T", key)
+	}
+}
+
+// FromMnemonicKey would reverse ToMnemonicKey. It always fails: see
+// errKeygenNotDeterministic.
+func FromMnemonicKey(mnemonic string) (interface{}, error) {
+	return nil, errKeygenNotDeterministic
+}
@@ -0,0 +1,38 @@
+package melt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMnemonicKeyRejectsECDSA(t *testing.T) {
+	is := is.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	is.NoErr(err)
+
+	_, err = ToMnemonicKey(key)
+	is.True(err != nil)
+}
+
+func TestMnemonicKeyRejectsRSA(t *testing.T) {
+	is := is.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048) //nolint: gomnd
+	is.NoErr(err)
+
+	_, err = ToMnemonicKey(key)
+	is.True(err != nil)
+}
+
+func TestFromMnemonicKeyRejects(t *testing.T) {
+	is := is.New(t)
+
+	_, err := FromMnemonicKey("any mnemonic")
+	is.True(err != nil)
+}
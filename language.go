@@ -0,0 +1,71 @@
+package melt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	lang "golang.org/x/text/language"
+)
+
+// detectWordLists maps each BIP-39 wordlist melt ships with go-bip39 to its
+// language tag, for DetectLanguage to score mnemonics against.
+var detectWordLists = map[lang.Tag][]string{
+	lang.SimplifiedChinese:  wordlists.ChineseSimplified,
+	lang.TraditionalChinese: wordlists.ChineseTraditional,
+	lang.Czech:              wordlists.Czech,
+	lang.English:            wordlists.English,
+	lang.French:             wordlists.French,
+	lang.Italian:            wordlists.Italian,
+	lang.Japanese:           wordlists.Japanese,
+	lang.Korean:             wordlists.Korean,
+	lang.Spanish:            wordlists.Spanish,
+}
+
+// DetectLanguage tokenizes mnemonic and returns the language of the unique
+// BIP-39 wordlist every token belongs to. It returns an error if no
+// wordlist matches every token, or if more than one does (which can happen
+// for short words shared between, e.g., English and French).
+func DetectLanguage(mnemonic string) (lang.Tag, error) {
+	tokens := tokenizeMnemonic(mnemonic)
+	if len(tokens) == 0 {
+		return lang.Und, fmt.Errorf("mnemonic has no words")
+	}
+
+	var matches []lang.Tag
+	for tag, wl := range detectWordLists {
+		if allTokensKnown(tokens, wl) {
+			matches = append(matches, tag)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return lang.Und, fmt.Errorf("could not detect mnemonic language: no wordlist contains every word")
+	case 1:
+		return matches[0], nil
+	default:
+		return lang.Und, fmt.Errorf("could not detect mnemonic language: %d wordlists match, try passing --language explicitly", len(matches))
+	}
+}
+
+// tokenizeMnemonic splits mnemonic on ASCII spaces as well as the
+// ideographic space (U+3000) used to join Japanese and Chinese phrases.
+func tokenizeMnemonic(mnemonic string) []string {
+	return strings.FieldsFunc(mnemonic, func(r rune) bool {
+		return r == ' ' || r == '　' || r == '\n' || r == '\t' || r == '\r'
+	})
+}
+
+func allTokensKnown(tokens, wordlist []string) bool {
+	known := make(map[string]struct{}, len(wordlist))
+	for _, w := range wordlist {
+		known[w] = struct{}{}
+	}
+	for _, t := range tokens {
+		if _, ok := known[t]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package melt
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	lang "golang.org/x/text/language"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	bip39.SetWordList(wordlists.Japanese)
+	mnemonic, err := ToMnemonic(&key)
+	is.NoErr(err)
+	bip39.SetWordList(wordlists.English)
+
+	tag, err := DetectLanguage(mnemonic)
+	is.NoErr(err)
+	is.Equal(tag, lang.Japanese)
+}
+
+func TestDetectLanguageNoMatch(t *testing.T) {
+	is := is.New(t)
+	_, err := DetectLanguage("this is not a bip39 mnemonic at all")
+	is.True(err != nil)
+}
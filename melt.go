@@ -0,0 +1,208 @@
+// Package melt turns SSH keys into BIP-39 mnemonic seed phrases, and back
+// again.
+package melt
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/melt/shamir"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// passphraseMarkerWords is how many extra words ToMnemonic appends to carry
+// the identifier Mask needs, at 11 bits (one wordlist index) apiece: enough
+// to hold idBits (15) bits with room to spare.
+const passphraseMarkerWords = 2
+
+// ToMnemonic converts the given ed25519 private key to a BIP-39 mnemonic.
+//
+// passphrase is optional and variadic only so existing single-argument
+// callers keep compiling unchanged; at most one may be given. Without a
+// passphrase, this is exactly the legacy behavior: key's seed is encoded
+// directly as the mnemonic's entropy. With a passphrase, the 24 real words
+// instead encode key's seed masked by shamir.Mask (the same reversible,
+// passphrase-derived XOR stream ToShamirShares uses), and two extra words
+// carry the random identifier that stream needs — so the phrase keeps
+// backing up the exact key it was given, and FromMnemonic with the same
+// passphrase recovers it exactly, while a wrong passphrase unmasks to a
+// different, equally valid-looking key instead of failing outright.
+func ToMnemonic(key *ed25519.PrivateKey, passphrase ...string) (string, error) {
+	pass, err := singleMnemonicPassphrase(passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	if pass == "" {
+		mnemonic, err := bip39.NewMnemonic(key.Seed())
+		if err != nil {
+			return "", fmt.Errorf("could not create mnemonic: %w", err)
+		}
+		return mnemonic, nil
+	}
+
+	identifier, err := shamir.NewIdentifier()
+	if err != nil {
+		return "", err
+	}
+	masked := shamir.Mask(key.Seed(), pass, identifier, shamir.DefaultIterationExponent)
+	mnemonic, err := bip39.NewMnemonic(masked)
+	if err != nil {
+		return "", fmt.Errorf("could not create mnemonic: %w", err)
+	}
+	return mnemonic + " " + encodePassphraseMarker(identifier), nil
+}
+
+// FromMnemonic converts the given BIP-39 mnemonic to an ed25519 private key.
+//
+// passphrase is optional and variadic only so existing single-argument
+// callers keep compiling unchanged; at most one may be given. It only
+// matters for mnemonics ToMnemonic generated with a passphrase of their
+// own, recognisable by their trailing marker words: for those, key's seed
+// was masked with shamir.Mask before encoding, so the same passphrase
+// unmasks it back to the original seed; a wrong passphrase unmasks to a
+// different, equally valid-looking key rather than an error, the same
+// plausible-deniability *behavior* stock BIP-39 passphrases have. This is
+// melt's own PBKDF2-SHA256/XOR construction (shared with ToShamirShares),
+// not the standard BIP-39 "25th word" HKDF-SHA512 derivation over
+// mnemonic+passphrase, so a given mnemonic and passphrase here will not
+// produce the same key as another BIP-39 implementation's passphrase
+// support.
+func FromMnemonic(mnemonic string, passphrase ...string) (ed25519.PrivateKey, error) {
+	pass, err := singleMnemonicPassphrase(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	phrase, identifier, versioned := splitPassphraseMarker(mnemonic)
+	if !bip39.IsMnemonicValid(phrase) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+
+	if !versioned {
+		if pass != "" {
+			return nil, fmt.Errorf("this mnemonic was not created with a passphrase")
+		}
+		seed, err := bip39.EntropyFromMnemonic(phrase)
+		if err != nil {
+			return nil, fmt.Errorf("could not get entropy from mnemonic: %w", err)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+
+	masked, err := bip39.EntropyFromMnemonic(phrase)
+	if err != nil {
+		return nil, fmt.Errorf("could not get entropy from mnemonic: %w", err)
+	}
+	seed := shamir.Mask(masked, pass, identifier, shamir.DefaultIterationExponent)
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// singleMnemonicPassphrase extracts the optional variadic passphrase
+// ToMnemonic and FromMnemonic accept, rejecting more than one.
+func singleMnemonicPassphrase(passphrase []string) (string, error) {
+	switch len(passphrase) {
+	case 0:
+		return "", nil
+	case 1:
+		return passphrase[0], nil
+	default:
+		return "", fmt.Errorf("at most one mnemonic passphrase may be given")
+	}
+}
+
+// encodePassphraseMarker renders identifier as passphraseMarkerWords extra
+// wordlist words: the top 11 bits of identifier as one word index, the
+// remaining low bits as a second. Their mere presence (and the resulting
+// 26-word length) is what tells FromMnemonic a phrase needs a passphrase to
+// restore; phrases without them decode exactly as they always have, so
+// existing 24-word mnemonics keep working unchanged.
+func encodePassphraseMarker(identifier uint16) string {
+	list := bip39.GetWordList()
+	hi := identifier >> 4
+	lo := identifier & 0xf
+	return list[hi] + " " + list[lo]
+}
+
+// splitPassphraseMarker reverses encodePassphraseMarker, reporting whether
+// mnemonic carries the trailing marker words ToMnemonic appends and, if so,
+// the identifier they encode, alongside the underlying 24-word BIP-39
+// phrase with them removed.
+func splitPassphraseMarker(mnemonic string) (string, uint16, bool) {
+	words := strings.Fields(mnemonic)
+	if len(words) != 24+passphraseMarkerWords {
+		return mnemonic, 0, false
+	}
+	hi, ok := bip39.GetWordIndex(words[24])
+	if !ok {
+		return mnemonic, 0, false
+	}
+	lo, ok := bip39.GetWordIndex(words[25])
+	if !ok || lo > 0xf {
+		return mnemonic, 0, false
+	}
+	return strings.Join(words[:24], " "), uint16(hi)<<4 | uint16(lo), true
+}
+
+// ToShamirShares splits the given ed25519 private key into count mnemonic
+// shares shaped like (but not interoperable with) SLIP-39's, any threshold
+// of which can later be passed to FromShamirShares to recover it. If
+// passphrase is non-empty, it mixes into the seed itself (not just an
+// on-disk encryption layer), so shares alone are useless without it.
+func ToShamirShares(key *ed25519.PrivateKey, threshold, count int, passphrase string) ([]string, error) {
+	identifier, err := shamir.NewIdentifier()
+	if err != nil {
+		return nil, err
+	}
+
+	secret := shamir.Mask(key.Seed(), passphrase, identifier, shamir.DefaultIterationExponent)
+	shares, err := shamir.Split(secret, threshold, count)
+	if err != nil {
+		return nil, fmt.Errorf("could not split key: %w", err)
+	}
+
+	out := make([]string, len(shares))
+	for i, s := range shares {
+		mnemonic, err := shamir.EncodeMnemonic(s, identifier, shamir.DefaultIterationExponent)
+		if err != nil {
+			return nil, fmt.Errorf("could not render share: %w", err)
+		}
+		out[i] = mnemonic
+	}
+	return out, nil
+}
+
+// FromShamirShares reverses ToShamirShares, recovering the ed25519 private
+// key from threshold or more of its shares. passphrase must match the one
+// given at split time, or a different (but equally valid-looking) key is
+// silently produced.
+func FromShamirShares(shares []string, passphrase string) (ed25519.PrivateKey, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("no shares given")
+	}
+
+	parsed := make([]shamir.Share, len(shares))
+	var identifier uint16
+	var iterationExponent int
+	for i, m := range shares {
+		share, id, iter, err := shamir.DecodeMnemonic(m)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse share %d: %w", i+1, err)
+		}
+		if i == 0 {
+			identifier, iterationExponent = id, iter
+		} else if id != identifier {
+			return nil, fmt.Errorf("share %d belongs to a different split", i+1)
+		}
+		parsed[i] = share
+	}
+
+	secret, err := shamir.Combine(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("could not combine shares: %w", err)
+	}
+
+	seed := shamir.Mask(secret, passphrase, identifier, iterationExponent)
+	return ed25519.NewKeyFromSeed(seed), nil
+}
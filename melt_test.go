@@ -0,0 +1,83 @@
+package melt
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMnemonicRoundTripNoPassphrase(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonic(&key)
+	is.NoErr(err)
+	is.Equal(len(strings.Fields(mnemonic)), 24)
+
+	got, err := FromMnemonic(mnemonic)
+	is.NoErr(err)
+	is.Equal(got, key)
+}
+
+func TestMnemonicPassphraseRoundTrip(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonic(&key, "correct horse battery staple")
+	is.NoErr(err)
+	is.Equal(len(strings.Fields(mnemonic)), 26)
+
+	got, err := FromMnemonic(mnemonic, "correct horse battery staple")
+	is.NoErr(err)
+	is.Equal(got, key)
+
+	again, err := FromMnemonic(mnemonic, "correct horse battery staple")
+	is.NoErr(err)
+	is.Equal(again, got)
+}
+
+func TestMnemonicWrongPassphraseYieldsDifferentKey(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonic(&key, "right passphrase")
+	is.NoErr(err)
+
+	got, err := FromMnemonic(mnemonic, "wrong passphrase")
+	is.NoErr(err)
+
+	want, err := FromMnemonic(mnemonic, "right passphrase")
+	is.NoErr(err)
+	is.True(!got.Equal(want))
+}
+
+func TestMnemonicUnversionedPhraseRejectsPassphrase(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	mnemonic, err := ToMnemonic(&key)
+	is.NoErr(err)
+
+	_, err = FromMnemonic(mnemonic, "some passphrase")
+	is.True(err != nil)
+}
+
+func TestMnemonicRejectsMultiplePassphrases(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	_, err = ToMnemonic(&key, "one", "two")
+	is.True(err != nil)
+}
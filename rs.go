@@ -0,0 +1,365 @@
+package melt
+
+import "errors"
+
+// This file implements a systematic Reed–Solomon code over GF(2^11), the
+// field whose 2048 elements line up one-to-one with the words of a BIP-39
+// wordlist (each word is an 11-bit symbol index). It backs the armored
+// mnemonic format in armor.go: a handful of substituted or erased words can
+// be corrected instead of merely detected.
+//
+// The encoder/decoder follow the standard systematic, syndrome-based
+// Reed–Solomon construction (generator roots alpha^0..alpha^(nsym-1),
+// Berlekamp–Massey for the error locator, Forney's algorithm for the error
+// magnitudes), generalized to accept a set of known erasure positions
+// alongside unknown errors.
+
+const (
+	gfBits     = 11
+	gfSize     = 1 << gfBits
+	gfOrder    = gfSize - 1
+	gfPrimPoly = 0x805 // x^11 + x^2 + 1
+)
+
+var (
+	gfExp [gfOrder * 2]uint16
+	gfLog [gfSize]uint16
+)
+
+func init() {
+	x := 1
+	for i := 0; i < gfOrder; i++ {
+		gfExp[i] = uint16(x)
+		gfLog[x] = uint16(i)
+		x <<= 1
+		if x&gfSize != 0 {
+			x ^= gfPrimPoly
+		}
+	}
+	for i := gfOrder; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-gfOrder]
+	}
+}
+
+// alphaPow returns the primitive element alpha (= 2 in this field) raised to
+// the n-th power, for any integer n (including negative exponents).
+func alphaPow(n int) uint16 {
+	n %= gfOrder
+	if n < 0 {
+		n += gfOrder
+	}
+	return gfExp[n]
+}
+
+func gfMul(a, b uint16) uint16 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b uint16) uint16 {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])-int(gfLog[b])+gfOrder)%gfOrder]
+}
+
+func gfInv(a uint16) uint16 {
+	return gfExp[gfOrder-int(gfLog[a])]
+}
+
+// rsPoly is a polynomial over GF(2^11), stored in descending-degree order:
+// rsPoly[0] is the coefficient of the highest power of x.
+type rsPoly []uint16
+
+func (p rsPoly) eval(x uint16) uint16 {
+	var y uint16
+	for _, c := range p {
+		y = gfMul(y, x) ^ c
+	}
+	return y
+}
+
+func rsPolyMul(a, b rsPoly) rsPoly {
+	out := make(rsPoly, len(a)+len(b)-1)
+	for i, ac := range a {
+		if ac == 0 {
+			continue
+		}
+		for j, bc := range b {
+			out[i+j] ^= gfMul(ac, bc)
+		}
+	}
+	return out
+}
+
+func rsPolyAdd(a, b rsPoly) rsPoly {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make(rsPoly, n)
+	copy(out[n-len(a):], a)
+	for i, c := range b {
+		out[n-len(b)+i] ^= c
+	}
+	return out
+}
+
+func rsPolyScale(p rsPoly, x uint16) rsPoly {
+	out := make(rsPoly, len(p))
+	for i, c := range p {
+		out[i] = gfMul(c, x)
+	}
+	return out
+}
+
+// rsPolyDivMod divides dividend by a monic divisor, both in descending order.
+func rsPolyDivMod(dividend, divisor rsPoly) (quotient, remainder rsPoly) {
+	rem := append(rsPoly{}, dividend...)
+	for i := 0; i <= len(rem)-len(divisor); i++ {
+		coef := rem[i]
+		if coef == 0 {
+			continue
+		}
+		for j, dc := range divisor {
+			if dc != 0 {
+				rem[i+j] ^= gfMul(dc, coef)
+			}
+		}
+	}
+	split := len(rem) - len(divisor) + 1
+	if split < 0 {
+		split = 0
+	}
+	return rem[:split], rem[split:]
+}
+
+func rsGeneratorPoly(nsym int) rsPoly {
+	g := rsPoly{1}
+	for i := 0; i < nsym; i++ {
+		g = rsPolyMul(g, rsPoly{1, alphaPow(i)})
+	}
+	return g
+}
+
+// rsEncode appends nsym systematic parity symbols to msg, returning the full
+// codeword (msg followed by parity).
+func rsEncode(msg []uint16, nsym int) []uint16 {
+	gen := rsGeneratorPoly(nsym)
+	padded := make(rsPoly, len(msg)+nsym)
+	copy(padded, msg)
+	_, remainder := rsPolyDivMod(padded, gen)
+	parity := make(rsPoly, nsym)
+	copy(parity[nsym-len(remainder):], remainder)
+	out := make([]uint16, 0, len(msg)+nsym)
+	out = append(out, msg...)
+	out = append(out, parity...)
+	return out
+}
+
+// rsCalcSyndromes returns nsym+1 syndromes; syndromes[0] is always 0 and kept
+// only so later indices line up with the Berlekamp–Massey recurrence below.
+func rsCalcSyndromes(msg rsPoly, nsym int) []uint16 {
+	synd := make([]uint16, nsym+1)
+	for i := 0; i < nsym; i++ {
+		synd[i+1] = msg.eval(alphaPow(i))
+	}
+	return synd
+}
+
+func rsSyndromesAllZero(synd []uint16) bool {
+	for _, s := range synd {
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// rsForneySyndromes folds known erasure positions out of the syndromes,
+// leaving syndromes that describe only the unknown errors.
+func rsForneySyndromes(synd []uint16, erasurePos []int, msgLen int) []uint16 {
+	fsynd := append([]uint16{}, synd[1:]...)
+	for _, p := range erasurePos {
+		x := alphaPow(msgLen - 1 - p)
+		for j := 0; j < len(fsynd)-1; j++ {
+			fsynd[j] = gfMul(fsynd[j], x) ^ fsynd[j+1]
+		}
+	}
+	return fsynd
+}
+
+// rsFindErrorLocator runs Berlekamp–Massey, seeded with the known erasure
+// locator when erasures are present, to find the combined errata locator
+// polynomial.
+func rsFindErrorLocator(synd []uint16, nsym int, eraseLoc rsPoly, eraseCount int) (rsPoly, error) {
+	var errLoc, oldLoc rsPoly
+	if eraseLoc != nil {
+		errLoc = append(rsPoly{}, eraseLoc...)
+		oldLoc = append(rsPoly{}, eraseLoc...)
+	} else {
+		errLoc = rsPoly{1}
+		oldLoc = rsPoly{1}
+	}
+
+	syndShift := 0
+	if len(synd) > nsym {
+		syndShift = len(synd) - nsym
+	}
+
+	for i := 0; i < nsym-eraseCount; i++ {
+		var k int
+		if eraseLoc != nil {
+			k = eraseCount + i + syndShift
+		} else {
+			k = i + syndShift
+		}
+		delta := synd[k]
+		for j := 1; j < len(errLoc); j++ {
+			delta ^= gfMul(errLoc[len(errLoc)-1-j], synd[k-j])
+		}
+
+		oldLoc = append(oldLoc, 0)
+		if delta == 0 {
+			continue
+		}
+		if len(oldLoc) > len(errLoc) {
+			newLoc := rsPolyScale(oldLoc, delta)
+			oldLoc = rsPolyScale(errLoc, gfInv(delta))
+			errLoc = newLoc
+		}
+		errLoc = rsPolyAdd(errLoc, rsPolyScale(oldLoc, delta))
+	}
+
+	for len(errLoc) > 0 && errLoc[0] == 0 {
+		errLoc = errLoc[1:]
+	}
+	errs := len(errLoc) - 1
+	if (errs-eraseCount)*2+eraseCount > nsym {
+		return nil, errors.New("too many errors to correct")
+	}
+	return errLoc, nil
+}
+
+func rsFindErrataLocator(coefPos []int) rsPoly {
+	loc := rsPoly{1}
+	for _, p := range coefPos {
+		loc = rsPolyMul(loc, rsPoly{alphaPow(p), 1})
+	}
+	return loc
+}
+
+func rsFindErrorEvaluator(synd, errLoc rsPoly, nsym int) rsPoly {
+	divisor := make(rsPoly, nsym+2)
+	divisor[0] = 1
+	_, remainder := rsPolyDivMod(rsPolyMul(synd, errLoc), divisor)
+	return remainder
+}
+
+func reverseU16(s []uint16) []uint16 {
+	out := make([]uint16, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+	return out
+}
+
+// rsFindErrors locates the roots of the errata locator polynomial via Chien
+// search, returning the corresponding positions in msg (0-indexed from the
+// start, matching errPos elsewhere in this file).
+func rsFindErrors(errLoc rsPoly, msgLen int) ([]int, error) {
+	reversed := reverseU16(errLoc)
+	errs := len(errLoc) - 1
+	var pos []int
+	for i := 0; i < msgLen; i++ {
+		if rsPoly(reversed).eval(alphaPow(i)) == 0 {
+			pos = append(pos, msgLen-1-i)
+		}
+	}
+	if len(pos) != errs {
+		return nil, errors.New("could not locate all errors")
+	}
+	return pos, nil
+}
+
+// rsCorrectErrata applies Forney's algorithm to correct msg in place at the
+// given positions (a mix of known erasures and located errors), given the
+// syndromes computed from the uncorrected msg.
+func rsCorrectErrata(msg []uint16, synd []uint16, errPos []int) ([]uint16, error) {
+	coefPos := make([]int, len(errPos))
+	for i, p := range errPos {
+		coefPos[i] = len(msg) - 1 - p
+	}
+	errLoc := rsFindErrataLocator(coefPos)
+	errEval := reverseU16(rsFindErrorEvaluator(reverseU16(synd), errLoc, len(errLoc)-1))
+
+	xs := make([]uint16, len(coefPos))
+	for i, p := range coefPos {
+		xs[i] = alphaPow(p)
+	}
+
+	out := append([]uint16{}, msg...)
+	for i, xi := range xs {
+		xiInv := gfInv(xi)
+
+		var errLocPrime uint16 = 1
+		for j, xj := range xs {
+			if j != i {
+				errLocPrime = gfMul(errLocPrime, 1^gfMul(xiInv, xj))
+			}
+		}
+		if errLocPrime == 0 {
+			return nil, errors.New("forney algorithm failed: could not compute error magnitude")
+		}
+
+		y := rsPoly(reverseU16(errEval)).eval(xiInv)
+		y = gfMul(xi, y)
+		magnitude := gfDiv(y, errLocPrime)
+		out[errPos[i]] ^= magnitude
+	}
+	return out, nil
+}
+
+// rsDecode corrects msg (a codeword of k+nsym symbols, k = len(msg)-nsym)
+// against up to nsym/2 unknown errors, or up to nsym known erasures (marked
+// via erasurePos; their value in msg is ignored), or a mix of both so long as
+// 2*errors+erasures <= nsym. It returns the corrected codeword.
+func rsDecode(msg []uint16, nsym int, erasurePos []int) ([]uint16, error) {
+	if len(erasurePos) > nsym {
+		return nil, errors.New("too many erasures to correct")
+	}
+
+	work := append([]uint16{}, msg...)
+	for _, p := range erasurePos {
+		work[p] = 0
+	}
+
+	synd := rsCalcSyndromes(work, nsym)
+	if rsSyndromesAllZero(synd) {
+		return work, nil
+	}
+
+	fsynd := rsForneySyndromes(synd, erasurePos, len(work))
+	errLoc, err := rsFindErrorLocator(fsynd, nsym, nil, len(erasurePos))
+	if err != nil {
+		return nil, err
+	}
+	newErrors, err := rsFindErrors(errLoc, len(work))
+	if err != nil {
+		return nil, err
+	}
+
+	allPos := append(append([]int{}, erasurePos...), newErrors...)
+	corrected, err := rsCorrectErrata(work, synd, allPos)
+	if err != nil {
+		return nil, err
+	}
+
+	finalSynd := rsCalcSyndromes(corrected, nsym)
+	if !rsSyndromesAllZero(finalSynd) {
+		return nil, errors.New("could not correct message")
+	}
+	return corrected, nil
+}
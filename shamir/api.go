@@ -0,0 +1,49 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// DefaultIterationExponent is used for every share produced by this package;
+// it is carried in the mnemonic itself so a future release can raise it
+// without breaking decoding of older shares.
+const DefaultIterationExponent = 1
+
+// NewIdentifier returns a random 15-bit identifier shared by every share of
+// one secret, so Combine can refuse to mix shares from unrelated splits.
+func NewIdentifier() (uint16, error) {
+	var b [2]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, fmt.Errorf("could not generate share identifier: %w", err)
+	}
+	return (uint16(b[0])<<8 | uint16(b[1])) & (1<<idBits - 1), nil
+}
+
+// Mask XORs secret with a passphrase-derived keystream, so that splitting
+// and rendering the result hides the real secret from anyone lacking the
+// passphrase, even if they recover `threshold` shares. Applying Mask twice
+// with the same passphrase and identifier recovers the original secret.
+func Mask(secret []byte, passphrase string, identifier uint16, iterationExponent int) []byte {
+	return xorBytes(secret, stretch(secret, passphrase, identifier, iterationExponent))
+}
+
+// EncodeMnemonic renders share as a checksummed mnemonic carrying identifier
+// and iterationExponent alongside it.
+func EncodeMnemonic(share Share, identifier uint16, iterationExponent int) (string, error) {
+	return encodeShare(header{
+		identifier:        identifier,
+		iterationExponent: iterationExponent,
+		memberIndex:       share.Index,
+		memberThreshold:   share.Threshold,
+	}, share.Value)
+}
+
+// DecodeMnemonic reverses EncodeMnemonic.
+func DecodeMnemonic(mnemonic string) (share Share, identifier uint16, iterationExponent int, err error) {
+	h, value, err := decodeShare(mnemonic)
+	if err != nil {
+		return Share{}, 0, 0, err
+	}
+	return Share{Index: h.memberIndex, Threshold: h.memberThreshold, Value: value}, h.identifier, h.iterationExponent, nil
+}
@@ -0,0 +1,82 @@
+package shamir
+
+// wordBits is the number of bits each wordlist entry encodes.
+const wordBits = 10
+
+// bitWriter accumulates an MSB-first bitstream, later read back out in
+// wordBits-sized chunks by words().
+type bitWriter struct {
+	bits []bool
+}
+
+func newBitWriter() *bitWriter { return &bitWriter{} }
+
+func (w *bitWriter) writeBits(v uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		w.bits = append(w.bits, v&(1<<uint(i)) != 0)
+	}
+}
+
+func (w *bitWriter) writeBytes(b []byte) {
+	for _, by := range b {
+		w.writeBits(uint32(by), 8)
+	}
+}
+
+func (w *bitWriter) padToMultipleOf(n int) {
+	for len(w.bits)%n != 0 {
+		w.bits = append(w.bits, false)
+	}
+}
+
+func (w *bitWriter) words() []uint16 {
+	out := make([]uint16, len(w.bits)/wordBits)
+	for i := range out {
+		var v uint16
+		for j := 0; j < wordBits; j++ {
+			v <<= 1
+			if w.bits[i*wordBits+j] {
+				v |= 1
+			}
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// bitReader reads an MSB-first bitstream back out of a sequence of
+// wordBits-sized words.
+type bitReader struct {
+	bits []bool
+	pos  int
+}
+
+func newBitReader(words []uint16) *bitReader {
+	bits := make([]bool, 0, len(words)*wordBits)
+	for _, word := range words {
+		for j := wordBits - 1; j >= 0; j-- {
+			bits = append(bits, word&(1<<uint(j)) != 0)
+		}
+	}
+	return &bitReader{bits: bits}
+}
+
+func (r *bitReader) readBits(n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		v <<= 1
+		if r.bits[r.pos] {
+			v |= 1
+		}
+		r.pos++
+	}
+	return v
+}
+
+func (r *bitReader) readBytes(n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte(r.readBits(8))
+	}
+	return out
+}
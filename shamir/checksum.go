@@ -0,0 +1,62 @@
+package shamir
+
+// This file implements a Bech32-style polynomial checksum over GF(1024): 3
+// trailing words computed from the share's other words plus a fixed
+// customization string, so a mistyped or corrupted word is detected before
+// it ever reaches Combine. The generator constants, customization string,
+// and polymod shape below match SLIP-39's RS1024, but that alone doesn't
+// make checksums interoperable with other SLIP-39 tools: the words they're
+// computed over come from melt's own wordlist, not the SLIP-39 one (see
+// the package doc comment in gf256.go), and that's permanent.
+var checksumGen = [5]uint32{
+	0xe0e040,
+	0x1c1c080,
+	0x3838100,
+	0x7070200,
+	0xe0e0009,
+}
+
+const checksumCustomization = "shamir"
+
+func polymod(values []uint16) uint32 {
+	var chk uint32 = 1
+	for _, v := range values {
+		top := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ uint32(v)
+		for i, gen := range checksumGen {
+			if top&(1<<uint(i)) != 0 {
+				chk ^= gen
+			}
+		}
+	}
+	return chk
+}
+
+func customizationSymbols() []uint16 {
+	out := make([]uint16, len(checksumCustomization))
+	for i, c := range checksumCustomization {
+		out[i] = uint16(c)
+	}
+	return out
+}
+
+// rsChecksum returns the checksumWords trailing words for body.
+func rsChecksum(body []uint16) []uint16 {
+	values := append(customizationSymbols(), body...)
+	values = append(values, 0, 0, 0)
+	chk := polymod(values) ^ 1
+
+	out := make([]uint16, checksumWords)
+	for i := range out {
+		shift := uint(10 * (checksumWords - 1 - i))
+		out[i] = uint16((chk >> shift) & 0x3ff)
+	}
+	return out
+}
+
+// rsVerify reports whether sum is the correct checksum for body.
+func rsVerify(body, sum []uint16) bool {
+	values := append(customizationSymbols(), body...)
+	values = append(values, sum...)
+	return polymod(values) == 1
+}
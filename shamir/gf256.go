@@ -0,0 +1,76 @@
+// Package shamir implements Shamir's Secret Sharing over GF(256): a secret
+// is split into shares such that any `threshold` of them reconstruct it,
+// but `threshold`-1 reveal nothing. It backs melt's `split`/`combine`
+// commands as an alternative to a single BIP-39 phrase.
+//
+// Shares are shaped like SLIP-39's (the header layout and RS1024 checksum,
+// see checksum.go, follow that spec), but are NOT interoperable with
+// SLIP-39 tools and never will be: this package only implements a single
+// share group (one threshold, one set of members), and renders shares with
+// a word list assembled from the project's existing BIP-39 English
+// wordlist rather than the canonical SLIP-39 list (see wordlist.go). Treat
+// this as melt's own share format, not a SLIP-39 implementation.
+package shamir
+
+// This file implements GF(256) arithmetic using the same reducing
+// polynomial as AES/Rijndael (x^8+x^4+x^3+x+1), which SLIP-39 also uses.
+// The construction mirrors the GF(2^11) implementation backing melt's
+// armored mnemonic mode (see rs.go), scaled down to 8-bit symbols.
+
+const gfPrimPoly = 0x11B
+
+// gfGenerator is 3, not 2: 2 only has order 51 under gfPrimPoly, so walking
+// its powers would cycle through a 51-element subgroup instead of the full
+// 255-element multiplicative group, leaving most byte values without a log.
+// 3 is a primitive root for this polynomial, same as AES's own tables use.
+const gfGenerator = 3
+
+var (
+	gfExp [255 * 2]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		gfExp[i] = x
+		gfLog[x] = byte(i)
+		x = gfMulSlow(x, gfGenerator)
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+// gfMulSlow multiplies two GF(256) elements by shift-and-add, reducing by
+// gfPrimPoly on overflow. It exists only to bootstrap the gfExp/gfLog
+// tables before gfMul (which depends on them) is available.
+func gfMulSlow(a, b byte) byte {
+	var product byte
+	for b != 0 {
+		if b&1 != 0 {
+			product ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= byte(gfPrimPoly & 0xFF)
+		}
+		b >>= 1
+	}
+	return product
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])-int(gfLog[b])+255)%255]
+}
@@ -0,0 +1,117 @@
+package shamir
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Mnemonic fields, packed MSB-first as a bitstream and then read back out in
+// wordlistSize-word (10-bit) chunks:
+//
+//	identifier (15 bits) | iteration exponent (4 bits) |
+//	group index (4 bits) | group threshold-1 (4 bits) | group count-1 (4 bits) |
+//	member index-1 (4 bits) | member threshold-1 (4 bits) |
+//	share value (8*len(secret) bits, zero-padded to a word boundary) |
+//	checksum (3 words)
+//
+// This package only ever emits a single group (index 0, threshold 1, count
+// 1), leaving room in the header for the multi-group nesting the full
+// SLIP-39 spec supports, should a future request need it.
+const (
+	idBits        = 15
+	iterBits      = 4
+	groupBits     = 4
+	memberBits    = 4
+	checksumWords = 3
+)
+
+type header struct {
+	identifier        uint16
+	iterationExponent int
+	memberIndex       int
+	memberThreshold   int
+}
+
+func encodeShare(h header, value []byte) (string, error) {
+	w := newBitWriter()
+	w.writeBits(uint32(h.identifier), idBits)
+	w.writeBits(uint32(h.iterationExponent), iterBits)
+	w.writeBits(0, groupBits) // group index
+	w.writeBits(0, groupBits) // group threshold - 1
+	w.writeBits(0, groupBits) // group count - 1
+	w.writeBits(uint32(h.memberIndex-1), memberBits)
+	w.writeBits(uint32(h.memberThreshold-1), memberBits)
+	w.writeBytes(value)
+	w.padToMultipleOf(wordBits)
+
+	words := w.words()
+	words = append(words, rsChecksum(words)...)
+
+	out := make([]string, len(words))
+	for i, sym := range words {
+		out[i] = wordlist[sym]
+	}
+	return strings.Join(out, " "), nil
+}
+
+func decodeShare(mnemonic string) (header, []byte, error) {
+	tokens := strings.Fields(mnemonic)
+	if len(tokens) <= checksumWords {
+		return header{}, nil, fmt.Errorf("share mnemonic is too short: got %d words", len(tokens))
+	}
+
+	index := wordIndex()
+	words := make([]uint16, len(tokens))
+	for i, tok := range tokens {
+		idx, ok := index[tok]
+		if !ok {
+			return header{}, nil, fmt.Errorf("word %q is not in the active wordlist", tok)
+		}
+		words[i] = idx
+	}
+
+	body, sum := words[:len(words)-checksumWords], words[len(words)-checksumWords:]
+	if !rsVerify(body, sum) {
+		return header{}, nil, fmt.Errorf("invalid checksum: share was mistyped or corrupted")
+	}
+
+	const headerBits = idBits + iterBits + 3*groupBits + 2*memberBits
+	if len(body)*wordBits < headerBits {
+		return header{}, nil, fmt.Errorf("malformed share: too short to contain a header")
+	}
+
+	r := newBitReader(body)
+	var h header
+	h.identifier = uint16(r.readBits(idBits))
+	h.iterationExponent = int(r.readBits(iterBits))
+	r.readBits(groupBits) // group index
+	r.readBits(groupBits) // group threshold - 1
+	r.readBits(groupBits) // group count - 1
+	h.memberIndex = int(r.readBits(memberBits)) + 1
+	h.memberThreshold = int(r.readBits(memberBits)) + 1
+
+	valueBits := len(body)*wordBits - idBits - iterBits - 3*groupBits - 2*memberBits
+	value := r.readBytes(valueBits / 8)
+	return h, value, nil
+}
+
+// stretch derives a passphrase-dependent keystream the same size as secret,
+// used to XOR-encrypt the master secret before it is split into shares (and
+// to reverse that after Combine). iterationExponent scales the PBKDF2 work
+// factor, mirroring SLIP-39's tunable iteration count.
+func stretch(secret []byte, passphrase string, identifier uint16, iterationExponent int) []byte {
+	salt := append([]byte("shamir"), byte(identifier>>8), byte(identifier))
+	iterations := 10000 << uint(iterationExponent)
+	return pbkdf2.Key([]byte(passphrase), salt, iterations, len(secret), sha256.New)
+}
+
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
@@ -0,0 +1,173 @@
+package shamir
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// digestIndex is the reserved x-coordinate SLIP-39 uses for the "digest
+// share": an extra point on the same polynomial whose value lets Combine
+// detect that it was handed a consistent set of shares before returning a
+// secret, rather than silently reconstructing garbage from a bad combo.
+const digestIndex = 254
+
+// Share is one point on the secret-sharing polynomial: Index is the x
+// coordinate (1-based member index) and Value holds len(secret) bytes of y
+// coordinates, one polynomial per byte position.
+type Share struct {
+	Index     int
+	Threshold int
+	Value     []byte
+}
+
+// Split divides secret into count shares such that any threshold of them
+// reconstruct it. threshold and count must be in [1, 16], and threshold <=
+// count.
+func Split(secret []byte, threshold, count int) ([]Share, error) {
+	if threshold < 1 || count < 1 || threshold > count {
+		return nil, fmt.Errorf("invalid threshold/count: %d of %d", threshold, count)
+	}
+	if count > 16 {
+		return nil, fmt.Errorf("at most 16 shares are supported, got %d", count)
+	}
+
+	if threshold == 1 {
+		shares := make([]Share, count)
+		for i := range shares {
+			shares[i] = Share{Index: i + 1, Threshold: 1, Value: append([]byte{}, secret...)}
+		}
+		return shares, nil
+	}
+
+	digest, err := digestShareValue(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fix f(0) = secret and f(digestIndex) = digest, then pick threshold-2
+	// further random points so the degree-(threshold-1) polynomial through
+	// all of them is uniquely determined.
+	points := make([]point, 0, threshold)
+	points = append(points, point{x: 0, y: secret})
+	points = append(points, point{x: digestIndex, y: digest})
+	for x := 1; x <= threshold-2; x++ {
+		y := make([]byte, len(secret))
+		if _, err := rand.Read(y); err != nil {
+			return nil, fmt.Errorf("could not generate random share: %w", err)
+		}
+		points = append(points, point{x: x, y: y})
+	}
+
+	shares := make([]Share, count)
+	for i := 0; i < count; i++ {
+		x := i + 1
+		var y []byte
+		if x <= threshold-2 {
+			y = points[x+1].y // points[0]=secret(x=0), points[1]=digest, points[2..]=x=1..threshold-2
+		} else {
+			y = interpolate(points, x)
+		}
+		shares[i] = Share{Index: x, Threshold: threshold, Value: y}
+	}
+	return shares, nil
+}
+
+// Combine reconstructs the original secret from threshold or more shares,
+// verifying the digest share before returning it.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("no shares given")
+	}
+	threshold := shares[0].Threshold
+	n := len(shares[0].Value)
+	seen := map[int]bool{}
+	points := make([]point, 0, len(shares))
+	for _, s := range shares {
+		if s.Threshold != threshold {
+			return nil, fmt.Errorf("shares disagree on threshold: %d vs %d", s.Threshold, threshold)
+		}
+		if len(s.Value) != n {
+			return nil, fmt.Errorf("shares have inconsistent lengths")
+		}
+		if seen[s.Index] {
+			return nil, fmt.Errorf("duplicate share index %d", s.Index)
+		}
+		seen[s.Index] = true
+		points = append(points, point{x: s.Index, y: s.Value})
+	}
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("need %d shares, got %d", threshold, len(shares))
+	}
+
+	secret := interpolate(points, 0)
+	if threshold == 1 {
+		return secret, nil
+	}
+
+	digest := interpolate(points, digestIndex)
+	if err := verifyDigestShareValue(secret, digest); err != nil {
+		return nil, fmt.Errorf("could not verify shares: %w", err)
+	}
+	return secret, nil
+}
+
+// digestShareValue builds the share value for the reserved digest point: the
+// first 4 bytes are an HMAC-SHA256 digest of the secret keyed by the
+// remaining random padding bytes, so tampering with either the secret or the
+// padding is detectable on Combine.
+func digestShareValue(secret []byte) ([]byte, error) {
+	pad := make([]byte, len(secret)-4)
+	if _, err := rand.Read(pad); err != nil {
+		return nil, fmt.Errorf("could not generate digest padding: %w", err)
+	}
+	return append(digestMAC(pad, secret), pad...), nil
+}
+
+func verifyDigestShareValue(secret, digestValue []byte) error {
+	if len(digestValue) < 4 {
+		return fmt.Errorf("digest share is too short")
+	}
+	want := digestMAC(digestValue[4:], secret)
+	got := digestValue[:4]
+	if !hmac.Equal(want, got) {
+		return fmt.Errorf("digest mismatch: shares do not belong together or are corrupted")
+	}
+	return nil
+}
+
+func digestMAC(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg) //nolint:errcheck
+	return mac.Sum(nil)[:4]
+}
+
+type point struct {
+	x int
+	y []byte
+}
+
+// interpolate evaluates, at x, the unique polynomial of degree len(points)-1
+// over GF(256) that passes through points, one independent polynomial per
+// byte position (Lagrange interpolation).
+func interpolate(points []point, x int) []byte {
+	n := len(points[0].y)
+	out := make([]byte, n)
+	for i := 0; i < n; i++ {
+		var y byte
+		for j, pj := range points {
+			var num, den byte = 1, 1
+			for k, pk := range points {
+				if k == j {
+					continue
+				}
+				num = gfMul(num, byte(x)^byte(pk.x))
+				den = gfMul(den, byte(pj.x)^byte(pk.x))
+			}
+			y ^= gfMul(pj.y[i], gfDiv(num, den))
+		}
+		out[i] = y
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func randomSecret(is *is.I) []byte {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	is.NoErr(err)
+	return secret
+}
+
+func TestSplitCombineRoundTrip(t *testing.T) {
+	is := is.New(t)
+	secret := randomSecret(is)
+
+	shares, err := Split(secret, 2, 3)
+	is.NoErr(err)
+	is.Equal(len(shares), 3)
+
+	got, err := Combine(shares[:2])
+	is.NoErr(err)
+	is.Equal(got, secret)
+
+	got, err = Combine([]Share{shares[0], shares[2]})
+	is.NoErr(err)
+	is.Equal(got, secret)
+}
+
+func TestCombineBelowThresholdFails(t *testing.T) {
+	is := is.New(t)
+	secret := randomSecret(is)
+
+	shares, err := Split(secret, 3, 5)
+	is.NoErr(err)
+
+	_, err = Combine(shares[:2])
+	is.True(err != nil)
+}
+
+func TestCombineTamperedShareFails(t *testing.T) {
+	is := is.New(t)
+	secret := randomSecret(is)
+
+	shares, err := Split(secret, 2, 3)
+	is.NoErr(err)
+
+	shares[0].Value[0] ^= 0xff
+
+	_, err = Combine(shares[:2])
+	is.True(err != nil)
+}
+
+func TestEncodeDecodeMnemonic(t *testing.T) {
+	is := is.New(t)
+	secret := randomSecret(is)
+
+	shares, err := Split(secret, 2, 3)
+	is.NoErr(err)
+
+	identifier, err := NewIdentifier()
+	is.NoErr(err)
+
+	m, err := EncodeMnemonic(shares[0], identifier, DefaultIterationExponent)
+	is.NoErr(err)
+
+	share, id, iter, err := DecodeMnemonic(m)
+	is.NoErr(err)
+	is.Equal(id, identifier)
+	is.Equal(iter, DefaultIterationExponent)
+	is.Equal(share, shares[0])
+}
+
+func TestDecodeMnemonicRejectsBadChecksum(t *testing.T) {
+	is := is.New(t)
+	secret := randomSecret(is)
+
+	shares, err := Split(secret, 2, 3)
+	is.NoErr(err)
+	identifier, err := NewIdentifier()
+	is.NoErr(err)
+
+	m, err := EncodeMnemonic(shares[0], identifier, DefaultIterationExponent)
+	is.NoErr(err)
+
+	words := strings.Fields(m)
+	first := wordIndex()[words[0]]
+	words[0] = wordlist[(first+1)%wordlistSize]
+
+	_, _, _, err = DecodeMnemonic(strings.Join(words, " "))
+	is.True(err != nil)
+}
+
+func TestDecodeMnemonicRejectsTooShortToHoldHeader(t *testing.T) {
+	is := is.New(t)
+
+	body := []uint16{0, 0, 0}
+	words := append(append([]uint16{}, body...), rsChecksum(body)...)
+
+	out := make([]string, len(words))
+	for i, sym := range words {
+		out[i] = wordlist[sym]
+	}
+
+	_, _, _, err := DecodeMnemonic(strings.Join(out, " "))
+	is.True(err != nil)
+}
@@ -0,0 +1,24 @@
+package shamir
+
+import "github.com/tyler-smith/go-bip39/wordlists"
+
+// wordlistSize is the number of words needed to address one 10-bit symbol,
+// matching the SLIP-39 wordlist shape (as opposed to BIP-39's 11-bit, 2048
+// word lists).
+const wordlistSize = 1024
+
+// wordlist renders and parses share mnemonics. It deliberately reuses the
+// first 1024 entries of the project's existing BIP-39 English wordlist
+// rather than shipping the official SLIP-39 list: this package is not, and
+// does not aim to be, interoperable with other SLIP-39 tools (see the
+// package doc comment in gf256.go), so there's no canonical list to be
+// faithful to here.
+var wordlist = wordlists.English[:wordlistSize]
+
+func wordIndex() map[string]uint16 {
+	index := make(map[string]uint16, len(wordlist))
+	for i, w := range wordlist {
+		index[w] = uint16(i)
+	}
+	return index
+}
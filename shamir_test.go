@@ -0,0 +1,41 @@
+package melt
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestShamirRoundTrip(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	shares, err := ToShamirShares(&key, 2, 3, "")
+	is.NoErr(err)
+	is.Equal(len(shares), 3)
+
+	got, err := FromShamirShares(shares[:2], "")
+	is.NoErr(err)
+	is.Equal(got, key)
+}
+
+func TestShamirRoundTripWithPassphrase(t *testing.T) {
+	is := is.New(t)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	is.NoErr(err)
+
+	shares, err := ToShamirShares(&key, 2, 3, "hunter2")
+	is.NoErr(err)
+
+	got, err := FromShamirShares(shares[1:], "hunter2")
+	is.NoErr(err)
+	is.Equal(got, key)
+
+	wrong, err := FromShamirShares(shares[1:], "wrong")
+	is.NoErr(err)
+	is.True(!wrong.Equal(key))
+}